@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+
+	"github.com/IgorBayerl/AdlerCov/internal/analyzer"
+	"github.com/IgorBayerl/AdlerCov/internal/language"
+	"github.com/IgorBayerl/AdlerCov/internal/logging"
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+	"github.com/IgorBayerl/AdlerCov/internal/parsers"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter/diffreport"
+)
+
+// diffFlags holds the flags for the "diff" subcommand: a base and head
+// report (or glob) to compare, an optional list of files to restrict the
+// diff to, and where/how to render the result.
+type diffFlags struct {
+	base *string
+	head *string
+
+	sourceDirs      *string
+	changedFiles    *string
+	changedFilesGit *string
+	outputDir       *string
+
+	verbosity *string
+	logFile   *string
+	logFormat *string
+}
+
+func parseDiffFlags(args []string) (*diffFlags, error) {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+
+	df := &diffFlags{
+		base:            fs.String("base", "", "Base coverage report file path or pattern"),
+		head:            fs.String("head", "", "Head coverage report file path or pattern"),
+		sourceDirs:      fs.String("sourcedirs", "", "Source directories (comma-separated), applied to both base and head"),
+		changedFiles:    fs.String("changedfiles", "", "Comma-separated list of file paths to restrict the diff to"),
+		changedFilesGit: fs.String("changedfilesgit", "", "Restrict the diff to files changed between two git revisions, e.g. -changedfilesgit=main..HEAD (overrides -changedfiles)"),
+		outputDir:       fs.String("output", "coverage-diff", "Output directory for the diff report"),
+		verbosity:       fs.String("verbosity", "Error", "Logging level: Verbose, Info, Warning, Error, Off"),
+		logFile:         fs.String("logfile", "", "Write logs to this file as well as the console"),
+		logFormat:       fs.String("logformat", "text", "Log output format: text (default) or json"),
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if *df.base == "" || *df.head == "" {
+		return nil, errors.New("diff requires both -base and -head")
+	}
+	return df, nil
+}
+
+// asCliFlags adapts diffFlags to the cliFlags surface that
+// resolveAndValidateInputs/createReportConfiguration expect, pointed at
+// whichever side of the diff (base or head) is being resolved.
+func (df *diffFlags) asCliFlags(reportsPattern *string) *cliFlags {
+	empty := ""
+	ignoreNothing := ""
+	return &cliFlags{
+		reportsPatterns:   reportsPattern,
+		sourceDirs:        df.sourceDirs,
+		ignoreErrors:      &ignoreNothing,
+		assemblyFilters:   &empty,
+		classFilters:      &empty,
+		fileFilters:       &empty,
+		rhAssemblyFilters: &empty,
+		rhClassFilters:    &empty,
+		reportTypes:       &empty,
+		outputDir:         df.outputDir,
+		title:             &empty,
+		tag:               &empty,
+	}
+}
+
+// diffMain is the entry point for the "diff" subcommand: parse the base and
+// head reports independently with the same parser/language factories used
+// by generate, compare the resulting SummaryResults, optionally restrict the
+// result to a list of changed files (via -changedfiles or -changedfilesgit),
+// and render the delta as HTML, a text summary and JSON via diffreport.
+func diffMain(args []string) error {
+	df, err := parseDiffFlags(args)
+	if err != nil {
+		return err
+	}
+
+	verbosity, _ := logging.ParseVerbosity(strings.TrimSpace(*df.verbosity))
+	closer, err := logging.Init(&logging.Config{
+		Verbosity: verbosity,
+		File:      *df.logFile,
+		Format:    *df.logFormat,
+	})
+	if err != nil {
+		return fmt.Errorf("logger init error: %w", err)
+	}
+	if closer != nil {
+		defer closer.Close()
+	}
+	logger := slog.Default()
+
+	langFactory := buildLanguageFactory()
+	parserFactory := buildParserFactory()
+
+	baseSummary, err := parseSide(logger, df, df.base, langFactory, parserFactory)
+	if err != nil {
+		return fmt.Errorf("failed to parse -base report(s): %w", err)
+	}
+	headSummary, err := parseSide(logger, df, df.head, langFactory, parserFactory)
+	if err != nil {
+		return fmt.Errorf("failed to parse -head report(s): %w", err)
+	}
+
+	diff := analyzer.DiffSummaries(baseSummary, headSummary)
+	changed, err := resolveChangedFiles(df)
+	if err != nil {
+		return fmt.Errorf("failed to resolve changed files: %w", err)
+	}
+	if len(changed) > 0 {
+		diff = filterToChangedFiles(diff, changed)
+	}
+
+	if err := diffreport.NewDiffReportBuilder(*df.outputDir, logger).CreateReport(diff); err != nil {
+		return fmt.Errorf("failed to generate diff report: %w", err)
+	}
+	return nil
+}
+
+func parseSide(logger *slog.Logger, df *diffFlags, pattern *string, langFactory *language.ProcessorFactory, parserFactory *parsers.ParserFactory) (*model.SummaryResult, error) {
+	flags := df.asCliFlags(pattern)
+
+	actualReportFiles, invalidPatterns, err := resolveAndValidateInputs(logger, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	reportConfig, err := createReportConfiguration(flags, logging.Error, actualReportFiles, invalidPatterns, langFactory, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	ignoredCounts := make(analyzer.IgnoredErrorCounts)
+	return parseAndMergeReports(logger, reportConfig, parserFactory, ignoredCounts)
+}
+
+// resolveChangedFiles returns the changed-files list to restrict the diff
+// to, preferring -changedfilesgit (a "base..head"-style git revision range
+// passed to `git diff --name-only`) over the plain -changedfiles CSV when
+// both are set. Neither set means "don't restrict".
+func resolveChangedFiles(df *diffFlags) ([]string, error) {
+	if revRange := strings.TrimSpace(*df.changedFilesGit); revRange != "" {
+		return gitChangedFiles(revRange)
+	}
+	return splitAndTrim(*df.changedFiles), nil
+}
+
+// gitChangedFiles runs `git diff --name-only <revRange>` in the current
+// working directory and returns the resulting file paths, so CI can pass
+// e.g. -changedfilesgit="$BASE_SHA..$HEAD_SHA" instead of precomputing a CSV.
+func gitChangedFiles(revRange string) ([]string, error) {
+	out, err := exec.Command("git", "diff", "--name-only", revRange).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --name-only %s: %w", revRange, err)
+	}
+	return splitAndTrim(strings.ReplaceAll(strings.TrimSpace(string(out)), "\n", ",")), nil
+}
+
+// filterToChangedFiles drops every FileDiff whose path isn't in changed,
+// used for the -changedfiles "lines touched in this PR" view.
+func filterToChangedFiles(diff *model.CoverageDiff, changed []string) *model.CoverageDiff {
+	wanted := make(map[string]struct{}, len(changed))
+	for _, f := range changed {
+		wanted[f] = struct{}{}
+	}
+
+	filtered := &model.CoverageDiff{}
+	for _, assembly := range diff.Assemblies {
+		fa := &model.AssemblyDiff{Name: assembly.Name}
+		for _, class := range assembly.Classes {
+			fc := &model.ClassDiff{Name: class.Name}
+			for _, file := range class.Files {
+				if _, ok := wanted[file.Path]; ok {
+					fc.Files = append(fc.Files, file)
+				}
+			}
+			if len(fc.Files) > 0 {
+				fa.Classes = append(fa.Classes, fc)
+			}
+		}
+		if len(fa.Classes) > 0 {
+			filtered.Assemblies = append(filtered.Assemblies, fa)
+		}
+	}
+	return filtered
+}
+
+func splitAndTrim(csv string) []string {
+	if strings.TrimSpace(csv) == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(csv, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
@@ -0,0 +1,566 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/AdlerCov/internal/analyzer"
+	"github.com/IgorBayerl/AdlerCov/internal/filereader"
+	"github.com/IgorBayerl/AdlerCov/internal/glob"
+	"github.com/IgorBayerl/AdlerCov/internal/logging"
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+	"github.com/IgorBayerl/AdlerCov/internal/reportconfig"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter"
+	"github.com/IgorBayerl/AdlerCov/internal/settings"
+	"github.com/IgorBayerl/AdlerCov/internal/utils"
+
+	// reporters
+	"github.com/IgorBayerl/AdlerCov/internal/reporter/htmlreport"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter/lcov"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter/metricsreport"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter/sarifreport"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter/textsummary"
+
+	// language specific behaviours
+	"github.com/IgorBayerl/AdlerCov/internal/language"
+	"github.com/IgorBayerl/AdlerCov/internal/language/csharp"
+	"github.com/IgorBayerl/AdlerCov/internal/language/defaultformatter"
+	"github.com/IgorBayerl/AdlerCov/internal/language/golang"
+
+	// parsers
+	"github.com/IgorBayerl/AdlerCov/internal/parsers"
+	"github.com/IgorBayerl/AdlerCov/internal/parsers/cobertura"
+	"github.com/IgorBayerl/AdlerCov/internal/parsers/gocover"
+)
+
+var ErrMissingReportFlag = errors.New("missing required -report flag")
+
+// cliFlags holds the flags shared by every subcommand that parses and
+// renders coverage reports (today just "generate", but "merge" and
+// "validate" reuse the domain flags too).
+type cliFlags struct {
+	// domain
+	reportsPatterns   *string
+	outputDir         *string
+	reportTypes       *string
+	sourceDirs        *string
+	tag               *string
+	title             *string
+	assemblyFilters   *string
+	classFilters      *string
+	fileFilters       *string
+	rhAssemblyFilters *string
+	rhClassFilters    *string
+	ignoreErrors      *string
+
+	// logging
+	verbose   *bool
+	verbosity *string
+	logFile   *string
+	logFormat *string
+
+	// exit-code thresholds
+	failOn      *string
+	maxWarnings *int
+}
+
+// registerCliFlags registers the domain and logging flags shared across
+// subcommands onto fs and returns the handles to read them back after
+// fs.Parse. Used both by the "generate" subcommand's own FlagSet and by the
+// legacy top-level flag.CommandLine shim.
+func registerCliFlags(fs *flag.FlagSet) *cliFlags {
+	return &cliFlags{
+		reportsPatterns:   fs.String("report", "", "Coverage report file paths or patterns (semicolon-separated)"),
+		outputDir:         fs.String("output", "coverage-report", "Output directory for generated reports"),
+		reportTypes:       fs.String("reporttypes", "TextSummary,Html", "Report types (comma-separated)"),
+		sourceDirs:        fs.String("sourcedirs", "", "Source directories (comma-separated)"),
+		tag:               fs.String("tag", "", "Optional tag, e.g. build number"),
+		title:             fs.String("title", "", "Optional report title (default: 'Coverage Report')"),
+		assemblyFilters:   fs.String("assemblyfilters", "", "Assembly filters (+Include;-Exclude)"),
+		classFilters:      fs.String("classfilters", "", "Class filters"),
+		fileFilters:       fs.String("filefilters", "", "File filters"),
+		rhAssemblyFilters: fs.String("riskhotspotassemblyfilters", "", "Risk-hotspot assembly filters"),
+		rhClassFilters:    fs.String("riskhotspotclassfilters", "", "Risk-hotspot class filters"),
+		ignoreErrors:      fs.String("ignoreerrors", "", "Comma-separated error IDs to demote to warnings instead of failing the run (an unknown ID is rejected with the list of valid IDs)"),
+
+		verbose:   fs.Bool("verbose", false, "Shortcut for Verbose logging (overridden by -verbosity)"),
+		verbosity: fs.String("verbosity", "Error", "Logging level: Verbose, Info, Warning, Error, Off"),
+		logFile:   fs.String("logfile", "", "Write logs to this file as well as the console"),
+		logFormat: fs.String("logformat", "text", "Log output format: text (default) or json"),
+
+		failOn:      fs.String("failon", "none", "Exit non-zero once logged messages reach this severity: warn, error, or none (default: none, matching pre-threshold behavior)"),
+		maxWarnings: fs.Int("maxwarnings", -1, "Exit non-zero once logged warnings exceed this count (-1 disables the check)"),
+	}
+}
+
+func parseFlags() (*cliFlags, error) {
+	f := registerCliFlags(flag.CommandLine)
+
+	flag.Parse()
+
+	if _, err := reportconfig.ParseErrorIDs(*f.ignoreErrors); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func buildLogger(f *cliFlags) (logging.VerbosityLevel, *logging.Closer, error) {
+	verbosityStr := strings.TrimSpace(*f.verbosity)
+	level, err := logging.ParseVerbosity(verbosityStr)
+	if err != nil && verbosityStr != "" {
+		return 0, nil, err
+	}
+
+	switch {
+	case verbosityStr != "" && verbosityStr != "Error":
+	case *f.verbose:
+		level = logging.Verbose
+	default:
+		level = logging.Error
+	}
+
+	cfg := logging.Config{
+		Verbosity: level,
+		File:      *f.logFile,
+		Format:    *f.logFormat,
+	}
+	closer, err := logging.Init(&cfg)
+	return level, closer, err
+}
+
+// Helpers
+
+func resolveAndValidateInputs(logger *slog.Logger, flags *cliFlags) ([]string, []string, error) {
+	if *flags.reportsPatterns == "" {
+		return nil, nil, ErrMissingReportFlag
+	}
+
+	reportFilePatterns := strings.Split(*flags.reportsPatterns, ";")
+	var actualReportFiles []string
+	var invalidPatterns []string
+	seenFiles := make(map[string]struct{})
+
+	for _, pattern := range reportFilePatterns {
+		trimmedPattern := strings.TrimSpace(pattern)
+		if trimmedPattern == "" {
+			continue
+		}
+		expandedFiles, err := glob.GetFiles(trimmedPattern)
+		if err != nil {
+			logger.Warn("Error expanding report file pattern", "pattern", trimmedPattern, "error", err)
+			invalidPatterns = append(invalidPatterns, trimmedPattern)
+			continue
+		}
+		if len(expandedFiles) == 0 {
+			logger.Warn("No files found for report pattern", "pattern", trimmedPattern)
+			invalidPatterns = append(invalidPatterns, trimmedPattern)
+		}
+		for _, file := range expandedFiles {
+			absFile, _ := filepath.Abs(file)
+			if _, exists := seenFiles[absFile]; !exists {
+				if stat, err := os.Stat(absFile); err == nil && !stat.IsDir() {
+					actualReportFiles = append(actualReportFiles, absFile)
+					seenFiles[absFile] = struct{}{}
+				} else if err != nil {
+					logger.Warn("Could not stat file from pattern", "pattern", trimmedPattern, "file", absFile, "error", err)
+					invalidPatterns = append(invalidPatterns, file)
+				}
+			}
+		}
+	}
+
+	if len(actualReportFiles) == 0 {
+		return nil, invalidPatterns, fmt.Errorf("no valid report files found after expanding patterns")
+	}
+
+	logger.Info("Found report files", "count", len(actualReportFiles))
+	logger.Debug("Report file list", "files", strings.Join(actualReportFiles, ", "))
+	return actualReportFiles, invalidPatterns, nil
+}
+
+func createReportConfiguration(flags *cliFlags, verbosity logging.VerbosityLevel, actualReportFiles, invalidPatterns []string, langFactory *language.ProcessorFactory, logger *slog.Logger) (*reportconfig.ReportConfiguration, error) {
+	reportTypes := strings.Split(*flags.reportTypes, ",")
+	sourceDirsList := strings.Split(*flags.sourceDirs, ",")
+	assemblyFilterStrings := strings.Split(*flags.assemblyFilters, ";")
+	classFilterStrings := strings.Split(*flags.classFilters, ";")
+	fileFilterStrings := strings.Split(*flags.fileFilters, ";")
+	rhAssemblyFilterStrings := strings.Split(*flags.rhAssemblyFilters, ";")
+	rhClassFilterStrings := strings.Split(*flags.rhClassFilters, ";")
+
+	// Already validated when each subcommand parsed its flags; the error here
+	// can only be nil.
+	ignoredErrorIDs, _ := reportconfig.ParseErrorIDs(*flags.ignoreErrors)
+
+	opts := []reportconfig.Option{
+		reportconfig.WithLogger(logger),
+		reportconfig.WithVerbosity(verbosity),
+		reportconfig.WithInvalidPatterns(invalidPatterns),
+		reportconfig.WithTitle(*flags.title),
+		reportconfig.WithTag(*flags.tag),
+		reportconfig.WithSourceDirectories(sourceDirsList),
+		reportconfig.WithReportTypes(reportTypes),
+		reportconfig.WithFilters(
+			assemblyFilterStrings,
+			classFilterStrings,
+			fileFilterStrings,
+			rhAssemblyFilterStrings,
+			rhClassFilterStrings,
+		),
+		reportconfig.WithLanguageProcessorFactory(langFactory),
+		reportconfig.WithIgnoredErrors(ignoredErrorIDs),
+	}
+
+	return reportconfig.NewReportConfiguration(
+		actualReportFiles,
+		*flags.outputDir,
+		opts...,
+	)
+}
+
+// parseReportFiles iterates through the report file patterns, parses each valid file,
+// and returns the collected results, any unresolved source file paths, and any parsing errors.
+func parseReportFiles(logger *slog.Logger, reportConfig *reportconfig.ReportConfiguration, parserFactory *parsers.ParserFactory, ignoredCounts analyzer.IgnoredErrorCounts) ([]*parsers.ParserResult, []string, []string) {
+	var parserResults []*parsers.ParserResult
+	var parserErrors []string
+	var allUnresolvedFiles []string
+
+	for _, reportFile := range reportConfig.ReportFiles() {
+		logger.Info("Attempting to parse report file", "file", reportFile)
+		parserInstance, err := parserFactory.FindParserForFile(reportFile)
+		if err != nil {
+			msg := fmt.Sprintf("no suitable parser found for file %s: %v", reportFile, err)
+			parserErrors = append(parserErrors, msg)
+			logger.Warn(msg)
+			continue
+		}
+
+		logger.Info("Using parser for file", "parser", parserInstance.Name(), "file", reportFile)
+
+		result, err := parserInstance.Parse(reportFile, reportConfig)
+		if err != nil {
+			msg := fmt.Sprintf("error parsing file %s with %s: %v", reportFile, parserInstance.Name(), err)
+			parserErrors = append(parserErrors, msg)
+			if reportConfig.IsErrorIgnored(reportconfig.ErrParserParseError) {
+				ignoredCounts.Add(string(reportconfig.ErrParserParseError))
+				logger.Warn(msg)
+			} else {
+				logger.Error(msg)
+			}
+			continue
+		}
+
+		if len(result.UnresolvedSourceFiles) > 0 {
+			allUnresolvedFiles = append(allUnresolvedFiles, result.UnresolvedSourceFiles...)
+		}
+
+		parserResults = append(parserResults, result)
+		logger.Info("Successfully parsed file", "file", reportFile)
+
+		if len(reportConfig.SourceDirectories()) == 0 && len(result.SourceDirectories) > 0 {
+			logger.Info("Report specified source directories, updating configuration", "file", reportFile, "dirs", result.SourceDirectories)
+			if err := reportconfig.WithSourceDirectories(result.SourceDirectories)(reportConfig); err != nil {
+				logger.Warn("Failed to apply source directories", "error", err)
+			}
+		}
+	}
+
+	return parserResults, allUnresolvedFiles, parserErrors
+}
+
+func parseAndMergeReports(logger *slog.Logger, reportConfig *reportconfig.ReportConfiguration, parserFactory *parsers.ParserFactory, ignoredCounts analyzer.IgnoredErrorCounts) (*model.SummaryResult, error) {
+	parserResults, allUnresolvedFiles, parserErrors := parseReportFiles(logger, reportConfig, parserFactory, ignoredCounts)
+
+	var unresolvedStubs []*model.CodeFile
+
+	// any source files were not found.
+	if len(allUnresolvedFiles) > 0 {
+		uniqueUnresolvedFiles := utils.DistinctBy(allUnresolvedFiles, func(s string) string { return s })
+		ignored := reportConfig.IsErrorIgnored(reportconfig.ErrUnresolvedSourceFile)
+
+		logLevel := logger.Error
+		if ignored {
+			logLevel = logger.Warn
+		}
+		logLevel("Failed to find source files referenced in coverage report",
+			"count", len(uniqueUnresolvedFiles))
+
+		if !ignored {
+			logger.Error("This is a fatal error because it would result in an incorrect or empty report")
+			logger.Error("Please provide the root directory of your source code using the '-sourcedirs' flag")
+			logger.Error("Examples of missing files:")
+		}
+
+		limit := 5
+		if len(uniqueUnresolvedFiles) < limit {
+			limit = len(uniqueUnresolvedFiles)
+		}
+		for i := 0; i < limit; i++ {
+			logLevel("Missing file", "file", uniqueUnresolvedFiles[i])
+		}
+
+		if !ignored {
+			return nil, errors.New("failed to find source files referenced in coverage report")
+		}
+
+		ignoredCounts.Add(string(reportconfig.ErrUnresolvedSourceFile))
+		for _, f := range uniqueUnresolvedFiles {
+			unresolvedStubs = append(unresolvedStubs, analyzer.StubUnresolvedFile(f))
+		}
+	}
+
+	// no reports could be parsed at all
+	if len(parserResults) == 0 {
+		if reportConfig.IsErrorIgnored(reportconfig.ErrNoValidReportFiles) {
+			ignoredCounts.Add(string(reportconfig.ErrNoValidReportFiles))
+			logger.Warn("No coverage reports could be parsed successfully; continuing with an empty report", "parserErrors", len(parserErrors))
+		} else {
+			errMsg := "no coverage reports could be parsed successfully"
+			if len(parserErrors) > 0 {
+				errMsg = fmt.Sprintf("%s. Errors:\r\n- %s", errMsg, strings.Join(parserErrors, "\r\n- "))
+			}
+			return nil, errors.New(errMsg)
+		}
+	}
+
+	logger.Info("Merging parsed reports", "count", len(parserResults))
+	summaryResult, err := analyzer.MergeParserResults(parserResults, reportConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge parser results: %w", err)
+	}
+	summaryResult.UnresolvedFiles = append(summaryResult.UnresolvedFiles, unresolvedStubs...)
+	foldUnresolvedIntoAssemblies(summaryResult, unresolvedStubs)
+	logger.Info("Coverage data merged and analyzed")
+	return summaryResult, nil
+}
+
+// foldUnresolvedIntoAssemblies adds stubs as a synthetic "Unresolved"
+// assembly/class so they are part of the tree HTML/Lcov (and every other
+// ReportBuilder) actually walks, not just the informational
+// SummaryResult.UnresolvedFiles slice.
+func foldUnresolvedIntoAssemblies(summaryResult *model.SummaryResult, stubs []*model.CodeFile) {
+	if len(stubs) == 0 {
+		return
+	}
+	summaryResult.Assemblies = append(summaryResult.Assemblies, &model.Assembly{
+		Name: "Unresolved",
+		Classes: []*model.Class{{
+			Name:  "Unresolved",
+			Files: stubs,
+		}},
+	})
+}
+
+func generateReports(reportCtx reporter.IBuilderContext, summaryResult *model.SummaryResult) error {
+	logger := reportCtx.Logger()
+	reportConfig := reportCtx.ReportConfiguration()
+	outputDir := reportConfig.TargetDirectory()
+
+	logger.Info("Generating reports", "directory", outputDir)
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, reportType := range reportConfig.ReportTypes() {
+		trimmedType := strings.TrimSpace(reportType)
+		logger.Info("Generating report", "type", trimmedType)
+
+		switch trimmedType {
+		case "TextSummary":
+			if err := textsummary.NewTextReportBuilder(outputDir, logger).CreateReport(summaryResult); err != nil {
+				return fmt.Errorf("failed to generate text report: %w", err)
+			}
+		case "Html":
+			if err := htmlreport.NewHtmlReportBuilder(outputDir, reportCtx).CreateReport(summaryResult); err != nil {
+				return fmt.Errorf("failed to generate HTML report: %w", err)
+			}
+		case "Lcov":
+			if err := lcov.NewLcovReportBuilder(outputDir).CreateReport(summaryResult); err != nil {
+				return fmt.Errorf("failed to generate lcov report: %w", err)
+			}
+		case "Sarif":
+			if err := sarifreport.NewSarifReportBuilder(outputDir, reportCtx).CreateReport(summaryResult); err != nil {
+				return fmt.Errorf("failed to generate SARIF report: %w", err)
+			}
+		case "OpenMetrics":
+			if err := metricsreport.NewMetricsReportBuilder(outputDir).CreateReport(summaryResult); err != nil {
+				return fmt.Errorf("failed to generate OpenMetrics report: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// buildLanguageFactory creates all desired language processors and the
+// factory that holds them. Shared between the default generate flow and the
+// serve command.
+func buildLanguageFactory() *language.ProcessorFactory {
+	return language.NewProcessorFactory(
+		defaultformatter.NewDefaultProcessor(),
+		csharp.NewCSharpProcessor(),
+		golang.NewGoProcessor(),
+	)
+}
+
+// buildParserFactory creates the parser factory shared between the default
+// generate flow and the serve command.
+func buildParserFactory() *parsers.ParserFactory {
+	prodFileReader := filereader.NewDefaultReader()
+	return parsers.NewParserFactory(
+		cobertura.NewCoberturaParser(prodFileReader),
+		gocover.NewGoCoverParser(prodFileReader),
+	)
+}
+
+func run(flags *cliFlags) (analyzer.IgnoredErrorCounts, error) {
+	ignoredCounts := make(analyzer.IgnoredErrorCounts)
+	logger := slog.Default()
+
+	// Re-get the verbosity level from the flags, as it's needed for ReportConfiguration.
+	verbosityStr := strings.TrimSpace(*flags.verbosity)
+	verbosity, _ := logging.ParseVerbosity(verbosityStr)
+	if *flags.verbose {
+		verbosity = logging.Verbose
+	}
+
+	langFactory := buildLanguageFactory()
+	parserFactory := buildParserFactory()
+
+	actualReportFiles, invalidPatterns, err := resolveAndValidateInputs(logger, flags)
+	if err != nil {
+		if len(invalidPatterns) > 0 {
+			return ignoredCounts, fmt.Errorf("%w; invalid patterns: %s", err, strings.Join(invalidPatterns, ", "))
+		}
+		return ignoredCounts, err
+	}
+
+	// Pass the language factory to create the configuration
+	reportConfig, err := createReportConfiguration(flags, verbosity, actualReportFiles, invalidPatterns, langFactory, logger)
+	if err != nil {
+		return ignoredCounts, err
+	}
+
+	// Pass the parser factory to the parsing logic
+	summaryResult, err := parseAndMergeReports(logger, reportConfig, parserFactory, ignoredCounts)
+	if err != nil {
+		return ignoredCounts, err
+	}
+
+	reportCtx := reporter.NewBuilderContext(reportConfig, settings.NewSettings(), logger)
+	return ignoredCounts, generateReports(reportCtx, summaryResult)
+}
+
+// generateMain is the entry point for the "generate" subcommand: parse one
+// or more coverage reports and render them as the configured report types.
+// It is also what the backward-compatible no-subcommand invocation runs.
+func generateMain(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags := registerCliFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if _, err := reportconfig.ParseErrorIDs(*flags.ignoreErrors); err != nil {
+		fmt.Fprintln(os.Stderr, "flag error:", err)
+		return err
+	}
+
+	return runGenerate(flags)
+}
+
+// legacyGenerateMain backs the no-subcommand invocation: it parses flags
+// from the top-level flag.CommandLine (so usage/help text looks exactly
+// like it did before subcommands existed) and runs the same generate flow.
+func legacyGenerateMain(_ []string) error {
+	flags, err := parseFlags()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "flag error:", err)
+		return err
+	}
+	return runGenerate(flags)
+}
+
+// runGenerate wires up logging and executes the generate flow shared by the
+// "generate" subcommand and the legacy no-subcommand invocation.
+func runGenerate(flags *cliFlags) error {
+	start := time.Now()
+
+	_, closer, err := buildLogger(flags)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "logger init error:", err)
+		return err
+	}
+	if closer != nil {
+		defer closer.Close()
+	}
+
+	ignoredCounts, runErr := run(flags)
+	if runErr != nil {
+		slog.Error("An error occurred during report generation", "error", runErr)
+
+		if errors.Is(runErr, ErrMissingReportFlag) {
+			fmt.Fprintln(os.Stderr, "")
+			flag.Usage()
+		}
+	}
+
+	var warnings, logErrors int64
+	if closer != nil && closer.Counters != nil {
+		warnings = closer.Counters.Warnings()
+		logErrors = closer.Counters.Errors()
+	}
+
+	slog.Info("Report generation finished",
+		"duration", time.Since(start).Round(time.Millisecond),
+		"ignored", ignoredCounts.Total(),
+		"warnings", warnings,
+		"errors", logErrors)
+
+	if runErr != nil {
+		return runErr
+	}
+	if err := checkFailOnThreshold(flags, warnings, logErrors); err != nil {
+		slog.Error("Failing the run on a logging threshold", "error", err)
+		return err
+	}
+	return nil
+}
+
+// checkFailOnThreshold turns this run into a failure once logged
+// warnings/errors reach the severity configured by -failon, or once logged
+// warnings exceed -maxwarnings, even when run itself returned nil -- e.g. so
+// CI can catch a report that rendered successfully but parsed some files
+// with errors along the way.
+//
+// -failon defaults to "none": before these thresholds existed, a per-file
+// parse error that didn't abort run() (because other report files still
+// parsed successfully) was logged as an error but never failed the process.
+// Defaulting -failon to "error" would silently turn every such warning-grade
+// run into a CI failure, so the threshold stays opt-in; set -failon=error (or
+// =warn) explicitly to gate on it.
+func checkFailOnThreshold(flags *cliFlags, warnings, logErrors int64) error {
+	switch strings.ToLower(strings.TrimSpace(*flags.failOn)) {
+	case "error":
+		if logErrors > 0 {
+			return fmt.Errorf("failing due to %d logged error(s) (-failon=error)", logErrors)
+		}
+	case "warn":
+		if logErrors > 0 || warnings > 0 {
+			return fmt.Errorf("failing due to %d logged error(s) and %d logged warning(s) (-failon=warn)", logErrors, warnings)
+		}
+	case "none":
+		// no severity-based failure requested
+	default:
+		return fmt.Errorf("unknown -failon value %q, valid values are: warn, error, none", *flags.failOn)
+	}
+
+	if *flags.maxWarnings >= 0 && warnings > int64(*flags.maxWarnings) {
+		return fmt.Errorf("failing: %d logged warning(s) exceeds -maxwarnings=%d", warnings, *flags.maxWarnings)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
+
+func TestCheckFailOnThresholdDefaultNoneNeverFails(t *testing.T) {
+	flags := &cliFlags{failOn: strPtr("none"), maxWarnings: intPtr(-1)}
+	if err := checkFailOnThreshold(flags, 5, 5); err != nil {
+		t.Errorf("expected no error with -failon=none, got %v", err)
+	}
+}
+
+func TestCheckFailOnThresholdError(t *testing.T) {
+	flags := &cliFlags{failOn: strPtr("error"), maxWarnings: intPtr(-1)}
+
+	if err := checkFailOnThreshold(flags, 0, 0); err != nil {
+		t.Errorf("expected no error with zero logged errors, got %v", err)
+	}
+	if err := checkFailOnThreshold(flags, 3, 0); err != nil {
+		t.Errorf("-failon=error should tolerate warnings, got %v", err)
+	}
+	if err := checkFailOnThreshold(flags, 0, 1); err == nil {
+		t.Error("expected an error once a logged error is present")
+	}
+}
+
+func TestCheckFailOnThresholdWarn(t *testing.T) {
+	flags := &cliFlags{failOn: strPtr("warn"), maxWarnings: intPtr(-1)}
+
+	if err := checkFailOnThreshold(flags, 0, 0); err != nil {
+		t.Errorf("expected no error with nothing logged, got %v", err)
+	}
+	if err := checkFailOnThreshold(flags, 1, 0); err == nil {
+		t.Error("expected an error once a logged warning is present")
+	}
+}
+
+func TestCheckFailOnThresholdUnknownValue(t *testing.T) {
+	flags := &cliFlags{failOn: strPtr("bogus"), maxWarnings: intPtr(-1)}
+	if err := checkFailOnThreshold(flags, 0, 0); err == nil {
+		t.Error("expected an error for an unknown -failon value")
+	}
+}
+
+func TestCheckFailOnThresholdMaxWarnings(t *testing.T) {
+	flags := &cliFlags{failOn: strPtr("none"), maxWarnings: intPtr(2)}
+
+	if err := checkFailOnThreshold(flags, 2, 0); err != nil {
+		t.Errorf("expected no error at the -maxwarnings limit, got %v", err)
+	}
+	if err := checkFailOnThreshold(flags, 3, 0); err == nil {
+		t.Error("expected an error once -maxwarnings is exceeded")
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/IgorBayerl/AdlerCov/internal/analyzer"
+	"github.com/IgorBayerl/AdlerCov/internal/logging"
+	"github.com/IgorBayerl/AdlerCov/internal/reportconfig"
+)
+
+// mergeFlags holds the flags for the "merge" subcommand: enough of the
+// domain flags to locate and parse reports, plus where to write the
+// resulting normalized intermediate JSON.
+type mergeFlags struct {
+	reportsPatterns *string
+	sourceDirs      *string
+	ignoreErrors    *string
+	output          *string
+
+	verbosity *string
+	logFile   *string
+	logFormat *string
+}
+
+func parseMergeFlags(args []string) (*mergeFlags, error) {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+
+	mf := &mergeFlags{
+		reportsPatterns: fs.String("report", "", "Coverage report file paths or patterns (semicolon-separated)"),
+		sourceDirs:      fs.String("sourcedirs", "", "Source directories (comma-separated)"),
+		ignoreErrors:    fs.String("ignoreerrors", "", "Comma-separated error IDs to demote to warnings"),
+		output:          fs.String("output", "merged.json", "Path to write the normalized intermediate JSON to"),
+		verbosity:       fs.String("verbosity", "Error", "Logging level: Verbose, Info, Warning, Error, Off"),
+		logFile:         fs.String("logfile", "", "Write logs to this file as well as the console"),
+		logFormat:       fs.String("logformat", "text", "Log output format: text (default) or json"),
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if _, err := reportconfig.ParseErrorIDs(*mf.ignoreErrors); err != nil {
+		return nil, err
+	}
+	return mf, nil
+}
+
+// asCliFlags adapts mergeFlags to the cliFlags surface the shared
+// parsing/merging helpers expect. Only the fields merge actually uses are
+// populated; everything else is left at its zero value.
+func (mf *mergeFlags) asCliFlags() *cliFlags {
+	empty := ""
+	return &cliFlags{
+		reportsPatterns:   mf.reportsPatterns,
+		sourceDirs:        mf.sourceDirs,
+		ignoreErrors:      mf.ignoreErrors,
+		assemblyFilters:   &empty,
+		classFilters:      &empty,
+		fileFilters:       &empty,
+		rhAssemblyFilters: &empty,
+		rhClassFilters:    &empty,
+		reportTypes:       &empty,
+		outputDir:         &empty,
+		title:             &empty,
+		tag:               &empty,
+	}
+}
+
+// mergeMain is the entry point for the "merge" subcommand: parse every
+// matched report and write the merged, normalized coverage data as a single
+// JSON file, without rendering any human-facing report.
+func mergeMain(args []string) error {
+	mf, err := parseMergeFlags(args)
+	if err != nil {
+		return err
+	}
+
+	verbosity, _ := logging.ParseVerbosity(strings.TrimSpace(*mf.verbosity))
+	closer, err := logging.Init(&logging.Config{
+		Verbosity: verbosity,
+		File:      *mf.logFile,
+		Format:    *mf.logFormat,
+	})
+	if err != nil {
+		return fmt.Errorf("logger init error: %w", err)
+	}
+	if closer != nil {
+		defer closer.Close()
+	}
+	logger := slog.Default()
+
+	flags := mf.asCliFlags()
+	langFactory := buildLanguageFactory()
+	parserFactory := buildParserFactory()
+
+	actualReportFiles, invalidPatterns, err := resolveAndValidateInputs(logger, flags)
+	if err != nil {
+		return err
+	}
+
+	reportConfig, err := createReportConfiguration(flags, verbosity, actualReportFiles, invalidPatterns, langFactory, logger)
+	if err != nil {
+		return err
+	}
+
+	ignoredCounts := make(analyzer.IgnoredErrorCounts)
+	summaryResult, err := parseAndMergeReports(logger, reportConfig, parserFactory, ignoredCounts)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(summaryResult, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal merged summary: %w", err)
+	}
+	if err := os.WriteFile(*mf.output, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write merged summary to %s: %w", *mf.output, err)
+	}
+
+	logger.Info("Wrote merged coverage summary", "file", *mf.output, "ignored", ignoredCounts.Total())
+	return nil
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bep/debounce"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/IgorBayerl/AdlerCov/internal/analyzer"
+	"github.com/IgorBayerl/AdlerCov/internal/glob"
+	"github.com/IgorBayerl/AdlerCov/internal/logging"
+	"github.com/IgorBayerl/AdlerCov/internal/reportconfig"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter/htmlreport"
+	"github.com/IgorBayerl/AdlerCov/internal/settings"
+)
+
+// serveFlags holds the flags specific to the serve command. It embeds the
+// same domain flags used by generate so -report/-sourcedirs/etc. behave
+// identically in both modes.
+type serveFlags struct {
+	*cliFlags
+
+	bindAddr            *string
+	port                *int
+	pollInterval        *time.Duration
+	disableBrowserError *bool
+}
+
+func parseServeFlags(args []string) (*serveFlags, error) {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+
+	sf := &serveFlags{
+		cliFlags: &cliFlags{
+			reportsPatterns:   fs.String("report", "", "Coverage report file paths or patterns (semicolon-separated)"),
+			outputDir:         fs.String("output", "coverage-report", "Output directory for generated reports"),
+			reportTypes:       fs.String("reporttypes", "Html", "Report types (comma-separated)"),
+			sourceDirs:        fs.String("sourcedirs", "", "Source directories (comma-separated)"),
+			ignoreErrors:      fs.String("ignoreerrors", "", "Comma-separated error IDs to demote to warnings"),
+			verbosity:         fs.String("verbosity", "Info", "Logging level: Verbose, Info, Warning, Error, Off"),
+			logFile:           fs.String("logfile", "", "Write logs to this file as well as the console"),
+			logFormat:         fs.String("logformat", "text", "Log output format: text (default) or json"),
+			verbose:           fs.Bool("verbose", false, "Shortcut for Verbose logging"),
+			title:             fs.String("title", "", "Optional report title"),
+			tag:               fs.String("tag", "", "Optional tag"),
+			assemblyFilters:   fs.String("assemblyfilters", "", "Assembly filters (+Include;-Exclude)"),
+			classFilters:      fs.String("classfilters", "", "Class filters"),
+			fileFilters:       fs.String("filefilters", "", "File filters"),
+			rhAssemblyFilters: fs.String("riskhotspotassemblyfilters", "", "Risk-hotspot assembly filters"),
+			rhClassFilters:    fs.String("riskhotspotclassfilters", "", "Risk-hotspot class filters"),
+		},
+		bindAddr:            fs.String("bind", "127.0.0.1", "Address the serve command binds to"),
+		port:                fs.Int("port", 1331, "Port the serve command listens on"),
+		pollInterval:        fs.Duration("poll", 0, "Rebuild on this interval instead of relying on fsnotify (useful on network filesystems); every tick rebuilds, whether or not anything changed"),
+		disableBrowserError: fs.Bool("disablebrowsererror", false, "Do not overlay rebuild errors in served pages"),
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if _, err := reportconfig.ParseErrorIDs(*sf.ignoreErrors); err != nil {
+		return nil, err
+	}
+	return sf, nil
+}
+
+// serveState is the shared, mutex-guarded state the HTTP handlers and the
+// watcher goroutine both touch: the last rebuild error (rendered as an
+// in-page overlay) and the set of open reload notification channels.
+type serveState struct {
+	mu         sync.Mutex
+	lastErr    error
+	subscriber map[chan struct{}]struct{}
+}
+
+func newServeState() *serveState {
+	return &serveState{subscriber: make(map[chan struct{}]struct{})}
+}
+
+func (s *serveState) setError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastErr = err
+}
+
+func (s *serveState) notifyReload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscriber {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *serveState) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.subscriber[ch] = struct{}{}
+	s.mu.Unlock()
+	return ch
+}
+
+func (s *serveState) unsubscribe(ch chan struct{}) {
+	s.mu.Lock()
+	delete(s.subscriber, ch)
+	s.mu.Unlock()
+}
+
+// serveMain is the entry point for `adlercov serve`. It behaves like a
+// single generate run followed by a filesystem watch loop: every change
+// directly inside the watched report/source directories triggers a
+// debounced rebuild (see watchPaths), and
+// already-open browser tabs are told to reload via the /__livereload SSE
+// endpoint injected into the HTML report.
+func serveMain(args []string) error {
+	sf, err := parseServeFlags(args)
+	if err != nil {
+		return err
+	}
+
+	verbosity, _ := logging.ParseVerbosity(strings.TrimSpace(*sf.verbosity))
+	closer, err := logging.Init(&logging.Config{
+		Verbosity: verbosity,
+		File:      *sf.logFile,
+		Format:    *sf.logFormat,
+	})
+	if err != nil {
+		return fmt.Errorf("logger init error: %w", err)
+	}
+	if closer != nil {
+		defer closer.Close()
+	}
+	logger := slog.Default()
+
+	state := newServeState()
+	rebuild := func() {
+		ignoredCounts := make(analyzer.IgnoredErrorCounts)
+		langFactory := buildLanguageFactory()
+		parserFactory := buildParserFactory()
+
+		actualReportFiles, invalidPatterns, err := resolveAndValidateInputs(logger, sf.cliFlags)
+		if err != nil {
+			state.setError(err)
+			logger.Error("rebuild failed while resolving inputs", "error", err)
+			state.notifyReload()
+			return
+		}
+
+		reportConfig, err := createReportConfiguration(sf.cliFlags, verbosity, actualReportFiles, invalidPatterns, langFactory, logger)
+		if err != nil {
+			state.setError(err)
+			logger.Error("rebuild failed while building configuration", "error", err)
+			state.notifyReload()
+			return
+		}
+
+		summaryResult, err := parseAndMergeReports(logger, reportConfig, parserFactory, ignoredCounts)
+		if err != nil {
+			state.setError(err)
+			logger.Error("rebuild failed while parsing reports", "error", err)
+			state.notifyReload()
+			return
+		}
+
+		reportCtx := reporter.NewBuilderContext(reportConfig, settings.NewSettings(), logger)
+		err = htmlreport.NewHtmlReportBuilder(reportConfig.TargetDirectory(), reportCtx,
+			htmlreport.WithLiveReload("/__livereload")).CreateReport(summaryResult)
+
+		state.setError(err)
+		if err != nil {
+			logger.Error("rebuild failed while generating the report", "error", err)
+		} else {
+			logger.Info("rebuild complete")
+		}
+		state.notifyReload()
+	}
+
+	rebuild()
+
+	debounced := debounce.New(300 * time.Millisecond)
+	stop, err := watchPaths(logger, *sf.pollInterval, watchedPaths(sf.cliFlags), func() {
+		debounced(rebuild)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start filesystem watcher: %w", err)
+	}
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir(*sf.outputDir)))
+	mux.HandleFunc("/__livereload", func(w http.ResponseWriter, r *http.Request) {
+		serveLiveReload(w, r, state, *sf.disableBrowserError)
+	})
+
+	addr := fmt.Sprintf("%s:%d", *sf.bindAddr, *sf.port)
+	logger.Info("serving coverage report", "addr", addr, "output", *sf.outputDir)
+	return http.ListenAndServe(addr, mux)
+}
+
+// watchedPaths resolves the -report patterns and -sourcedirs into concrete
+// directories worth watching for changes.
+func watchedPaths(flags *cliFlags) []string {
+	var paths []string
+	for _, pattern := range strings.Split(*flags.reportsPatterns, ";") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if files, err := glob.GetFiles(pattern); err == nil {
+			for _, f := range files {
+				paths = append(paths, filepath.Dir(f))
+			}
+		}
+	}
+	for _, dir := range strings.Split(*flags.sourceDirs, ",") {
+		dir = strings.TrimSpace(dir)
+		if dir != "" {
+			paths = append(paths, dir)
+		}
+	}
+	return paths
+}
+
+// watchPaths watches the given directories and calls onChange whenever an
+// entry directly inside one of them changes; each directory is watched on
+// its own, so changes in its subdirectories are not seen. When pollInterval
+// is non-zero it does not use fsnotify: onChange is called on every tick,
+// without checking whether anything under paths changed.
+func watchPaths(logger *slog.Logger, pollInterval time.Duration, paths []string, onChange func()) (stop func(), err error) {
+	if pollInterval > 0 {
+		ticker := time.NewTicker(pollInterval)
+		go func() {
+			for range ticker.C {
+				onChange()
+			}
+		}()
+		return ticker.Stop, nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range paths {
+		if err := watcher.Add(p); err != nil {
+			logger.Warn("could not watch path", "path", p, "error", err)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				logger.Debug("filesystem event", "event", event.String())
+				onChange()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warn("watcher error", "error", err)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		watcher.Close()
+	}, nil
+}
+
+// serveLiveReload is a Server-Sent-Events endpoint: it emits a "reload"
+// event each time a rebuild completes so pages left open in a browser can
+// refresh themselves, and an "error" event carrying the last rebuild error
+// when -disablebrowsererror is not set.
+func serveLiveReload(w http.ResponseWriter, r *http.Request, state *serveState, disableBrowserError bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := state.subscribe()
+	defer state.unsubscribe(ch)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			state.mu.Lock()
+			lastErr := state.lastErr
+			state.mu.Unlock()
+
+			if lastErr != nil && !disableBrowserError {
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", strings.ReplaceAll(lastErr.Error(), "\n", " "))
+			} else {
+				fmt.Fprint(w, "event: reload\ndata: ok\n\n")
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/IgorBayerl/AdlerCov/internal/analyzer"
+	"github.com/IgorBayerl/AdlerCov/internal/logging"
+	"github.com/IgorBayerl/AdlerCov/internal/utils"
+)
+
+// validateFlags holds the flags for the "validate" subcommand: just enough
+// to locate reports and resolve source files, since nothing is rendered.
+type validateFlags struct {
+	reportsPatterns *string
+	sourceDirs      *string
+
+	verbosity *string
+	logFile   *string
+	logFormat *string
+}
+
+func parseValidateFlags(args []string) (*validateFlags, error) {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+
+	vf := &validateFlags{
+		reportsPatterns: fs.String("report", "", "Coverage report file paths or patterns (semicolon-separated)"),
+		sourceDirs:      fs.String("sourcedirs", "", "Source directories (comma-separated)"),
+		verbosity:       fs.String("verbosity", "Info", "Logging level: Verbose, Info, Warning, Error, Off"),
+		logFile:         fs.String("logfile", "", "Write logs to this file as well as the console"),
+		logFormat:       fs.String("logformat", "text", "Log output format: text (default) or json"),
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return vf, nil
+}
+
+func (vf *validateFlags) asCliFlags() *cliFlags {
+	empty := ""
+	ignoreNothing := ""
+	return &cliFlags{
+		reportsPatterns:   vf.reportsPatterns,
+		sourceDirs:        vf.sourceDirs,
+		ignoreErrors:      &ignoreNothing,
+		assemblyFilters:   &empty,
+		classFilters:      &empty,
+		fileFilters:       &empty,
+		rhAssemblyFilters: &empty,
+		rhClassFilters:    &empty,
+		reportTypes:       &empty,
+		outputDir:         &empty,
+		title:             &empty,
+		tag:               &empty,
+	}
+}
+
+// validateMain is the entry point for the "validate" subcommand: a dry run
+// that reports which parser matched each report file and which referenced
+// source files could not be resolved, without rendering a report. Parse
+// errors and unresolved sources are logged as warnings and do not fail the
+// process; it still fails when no report file can be found at all. It is
+// meant for diagnosing a report/sourcedirs setup before wiring it into CI.
+func validateMain(args []string) error {
+	vf, err := parseValidateFlags(args)
+	if err != nil {
+		return err
+	}
+
+	verbosity, _ := logging.ParseVerbosity(strings.TrimSpace(*vf.verbosity))
+	closer, err := logging.Init(&logging.Config{
+		Verbosity: verbosity,
+		File:      *vf.logFile,
+		Format:    *vf.logFormat,
+	})
+	if err != nil {
+		return fmt.Errorf("logger init error: %w", err)
+	}
+	if closer != nil {
+		defer closer.Close()
+	}
+	logger := slog.Default()
+
+	flags := vf.asCliFlags()
+	langFactory := buildLanguageFactory()
+	parserFactory := buildParserFactory()
+
+	actualReportFiles, invalidPatterns, err := resolveAndValidateInputs(logger, flags)
+	if err != nil {
+		return err
+	}
+	for _, pattern := range invalidPatterns {
+		logger.Warn("Pattern did not resolve to a file", "pattern", pattern)
+	}
+
+	reportConfig, err := createReportConfiguration(flags, verbosity, actualReportFiles, invalidPatterns, langFactory, logger)
+	if err != nil {
+		return err
+	}
+
+	_, allUnresolvedFiles, parserErrors := parseReportFiles(logger, reportConfig, parserFactory, make(analyzer.IgnoredErrorCounts))
+
+	if len(parserErrors) > 0 {
+		logger.Warn("Some report files failed to parse", "count", len(parserErrors))
+		for _, msg := range parserErrors {
+			logger.Warn(msg)
+		}
+	}
+
+	if len(allUnresolvedFiles) > 0 {
+		uniqueUnresolvedFiles := utils.DistinctBy(allUnresolvedFiles, func(s string) string { return s })
+		logger.Warn("Unresolved source files", "count", len(uniqueUnresolvedFiles))
+		for _, f := range uniqueUnresolvedFiles {
+			logger.Warn("Unresolved", "file", f)
+		}
+	}
+
+	logger.Info("Validation complete",
+		"reportFiles", len(actualReportFiles),
+		"parseErrors", len(parserErrors),
+		"unresolvedSourceFiles", len(allUnresolvedFiles))
+	return nil
+}
@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+)
+
+// DiffSummaries compares two merged SummaryResult snapshots, matching
+// assemblies, classes and files by name/path, and returns the per-file
+// line-coverage delta between them. A file present only in head is reported
+// as added and one present only in base as removed; every file present on
+// both sides is compared line by line.
+func DiffSummaries(base, head *model.SummaryResult) *model.CoverageDiff {
+	baseAssemblies := indexAssemblies(base)
+	headAssemblies := indexAssemblies(head)
+
+	diff := &model.CoverageDiff{}
+	for _, name := range unionSortedKeys(baseAssemblies, headAssemblies) {
+		diff.Assemblies = append(diff.Assemblies, diffAssembly(name, baseAssemblies[name], headAssemblies[name]))
+	}
+	return diff
+}
+
+func diffAssembly(name string, base, head *model.Assembly) *model.AssemblyDiff {
+	baseClasses := indexClasses(base)
+	headClasses := indexClasses(head)
+
+	ad := &model.AssemblyDiff{Name: name}
+	for _, className := range unionSortedKeys(baseClasses, headClasses) {
+		ad.Classes = append(ad.Classes, diffClass(className, baseClasses[className], headClasses[className]))
+	}
+	return ad
+}
+
+func diffClass(name string, base, head *model.Class) *model.ClassDiff {
+	baseFiles := indexFiles(base)
+	headFiles := indexFiles(head)
+
+	cd := &model.ClassDiff{Name: name}
+	for _, path := range unionSortedKeys(baseFiles, headFiles) {
+		cd.Files = append(cd.Files, diffFile(path, baseFiles[path], headFiles[path]))
+	}
+	return cd
+}
+
+func diffFile(path string, base, head *model.CodeFile) *model.FileDiff {
+	if base == nil {
+		return &model.FileDiff{Path: path, Added: true}
+	}
+	if head == nil {
+		return &model.FileDiff{Path: path, Removed: true}
+	}
+
+	baseHits := hitsByLine(base)
+	headHits := hitsByLine(head)
+
+	fd := &model.FileDiff{Path: path}
+	for _, lineNumber := range unionSortedIntKeys(baseHits, headHits) {
+		wasCovered := baseHits[lineNumber] > 0
+		isCovered := headHits[lineNumber] > 0
+		switch {
+		case !wasCovered && isCovered:
+			fd.NewlyCovered = append(fd.NewlyCovered, lineNumber)
+		case wasCovered && !isCovered:
+			fd.NewlyUncovered = append(fd.NewlyUncovered, lineNumber)
+		}
+	}
+	return fd
+}
+
+func hitsByLine(f *model.CodeFile) map[int]int {
+	hits := make(map[int]int, len(f.Lines))
+	for _, line := range f.Lines {
+		hits[line.Number] = line.Hits
+	}
+	return hits
+}
+
+func indexAssemblies(s *model.SummaryResult) map[string]*model.Assembly {
+	if s == nil {
+		return nil
+	}
+	idx := make(map[string]*model.Assembly, len(s.Assemblies))
+	for _, a := range s.Assemblies {
+		idx[a.Name] = a
+	}
+	return idx
+}
+
+func indexClasses(a *model.Assembly) map[string]*model.Class {
+	if a == nil {
+		return nil
+	}
+	idx := make(map[string]*model.Class, len(a.Classes))
+	for _, c := range a.Classes {
+		idx[c.Name] = c
+	}
+	return idx
+}
+
+func indexFiles(c *model.Class) map[string]*model.CodeFile {
+	if c == nil {
+		return nil
+	}
+	idx := make(map[string]*model.CodeFile, len(c.Files))
+	for _, f := range c.Files {
+		idx[f.Path] = f
+	}
+	return idx
+}
+
+func unionSortedKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		seen[k] = struct{}{}
+	}
+	for k := range b {
+		seen[k] = struct{}{}
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unionSortedIntKeys(a, b map[int]int) []int {
+	seen := make(map[int]struct{}, len(a)+len(b))
+	for k := range a {
+		seen[k] = struct{}{}
+	}
+	for k := range b {
+		seen[k] = struct{}{}
+	}
+	keys := make([]int, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+)
+
+func summaryWithFile(assembly, class, path string, hits ...int) *model.SummaryResult {
+	lines := make([]model.Line, len(hits))
+	for i, h := range hits {
+		lines[i] = model.Line{Number: i + 1, Hits: h}
+	}
+	return &model.SummaryResult{
+		Assemblies: []*model.Assembly{{
+			Name: assembly,
+			Classes: []*model.Class{{
+				Name:  class,
+				Files: []*model.CodeFile{{Path: path, Lines: lines}},
+			}},
+		}},
+	}
+}
+
+func TestDiffSummariesNewlyCoveredAndUncovered(t *testing.T) {
+	base := summaryWithFile("Asm", "Class", "file.go", 1, 0, 1)
+	head := summaryWithFile("Asm", "Class", "file.go", 0, 1, 1)
+
+	diff := DiffSummaries(base, head)
+
+	if len(diff.Assemblies) != 1 || len(diff.Assemblies[0].Classes) != 1 || len(diff.Assemblies[0].Classes[0].Files) != 1 {
+		t.Fatalf("unexpected diff shape: %+v", diff)
+	}
+	fd := diff.Assemblies[0].Classes[0].Files[0]
+	if !reflect.DeepEqual(fd.NewlyCovered, []int{2}) {
+		t.Errorf("NewlyCovered = %v, want [2]", fd.NewlyCovered)
+	}
+	if !reflect.DeepEqual(fd.NewlyUncovered, []int{1}) {
+		t.Errorf("NewlyUncovered = %v, want [1]", fd.NewlyUncovered)
+	}
+}
+
+func TestDiffSummariesAddedAndRemovedFiles(t *testing.T) {
+	base := summaryWithFile("Asm", "Class", "gone.go", 1)
+	head := summaryWithFile("Asm", "Class", "new.go", 1)
+
+	diff := DiffSummaries(base, head)
+
+	var gotAdded, gotRemoved bool
+	for _, f := range diff.Assemblies[0].Classes[0].Files {
+		switch f.Path {
+		case "new.go":
+			gotAdded = f.Added
+		case "gone.go":
+			gotRemoved = f.Removed
+		}
+	}
+	if !gotAdded {
+		t.Error("expected new.go to be marked Added")
+	}
+	if !gotRemoved {
+		t.Error("expected gone.go to be marked Removed")
+	}
+}
+
+func TestDiffSummariesNilSides(t *testing.T) {
+	head := summaryWithFile("Asm", "Class", "new.go", 1)
+
+	diff := DiffSummaries(nil, head)
+
+	if len(diff.Assemblies) != 1 || len(diff.Assemblies[0].Classes[0].Files) != 1 {
+		t.Fatalf("unexpected diff shape: %+v", diff)
+	}
+	if !diff.Assemblies[0].Classes[0].Files[0].Added {
+		t.Error("expected file to be marked Added when base is nil")
+	}
+}
@@ -0,0 +1,37 @@
+package analyzer
+
+import (
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+)
+
+// StubUnresolvedFile builds a zero-coverage placeholder for a source file
+// that a parser referenced but that could not be found on disk. It is used
+// when -ignoreerrors includes "unresolved-source-file": rather than aborting
+// the run, the file stays in the report with no line data (so no covered
+// lines) and HTML/Lcov output stays structurally complete.
+func StubUnresolvedFile(path string) *model.CodeFile {
+	return &model.CodeFile{
+		Path:  path,
+		Lines: nil,
+	}
+}
+
+// IgnoredErrorCounts tallies how many times each ignorable error condition
+// fired during a run, so the final summary can report them alongside
+// warnings and errors.
+type IgnoredErrorCounts map[string]int
+
+// Add increments the counter for id and returns the running total for it.
+func (c IgnoredErrorCounts) Add(id string) int {
+	c[id]++
+	return c[id]
+}
+
+// Total returns the sum of all ignored-error occurrences.
+func (c IgnoredErrorCounts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
@@ -0,0 +1,156 @@
+// Package logging configures the process-wide slog logger shared by every
+// subcommand: verbosity, optional file output, text/json formatting, and a
+// LogCounters tally of how many warnings/errors were actually logged so
+// callers can drive -failon / -maxwarnings style thresholds after a run
+// completes.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// VerbosityLevel controls which slog levels reach the configured handler.
+type VerbosityLevel int
+
+const (
+	Verbose VerbosityLevel = iota
+	Info
+	Warning
+	Error
+	Off
+)
+
+func (v VerbosityLevel) slogLevel() slog.Level {
+	switch v {
+	case Verbose:
+		return slog.LevelDebug
+	case Info:
+		return slog.LevelInfo
+	case Warning:
+		return slog.LevelWarn
+	case Error:
+		return slog.LevelError
+	default: // Off
+		return slog.LevelError + 4
+	}
+}
+
+// ParseVerbosity parses one of "Verbose", "Info", "Warning", "Error", "Off"
+// (case-insensitive). An empty string is rejected; callers that want a
+// default should check for it before calling ParseVerbosity.
+func ParseVerbosity(s string) (VerbosityLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "verbose":
+		return Verbose, nil
+	case "info":
+		return Info, nil
+	case "warning", "warn":
+		return Warning, nil
+	case "error":
+		return Error, nil
+	case "off":
+		return Off, nil
+	default:
+		return 0, fmt.Errorf("unknown verbosity %q, valid values are: Verbose, Info, Warning, Error, Off", s)
+	}
+}
+
+// Config configures Init.
+type Config struct {
+	Verbosity VerbosityLevel
+	File      string
+	Format    string // "text" (default) or "json"
+}
+
+// LogCounters atomically tallies how many messages were logged at Warn and
+// Error level, so a caller can implement -failon and -maxwarnings thresholds
+// once a run has finished. Ignored-error counts are a separate concern,
+// tracked by analyzer.IgnoredErrorCounts instead: that's a count of
+// -ignoreerrors conditions the caller demoted, not of log records emitted,
+// and the two don't always move together.
+type LogCounters struct {
+	warnings atomic.Int64
+	errors   atomic.Int64
+}
+
+// Warnings returns how many Warn-level records were logged.
+func (c *LogCounters) Warnings() int64 { return c.warnings.Load() }
+
+// Errors returns how many Error-level records were logged.
+func (c *LogCounters) Errors() int64 { return c.errors.Load() }
+
+// countingHandler wraps a slog.Handler, tallying Warn/Error records into
+// counters before forwarding them unchanged.
+type countingHandler struct {
+	slog.Handler
+	counters *LogCounters
+}
+
+func (h *countingHandler) Handle(ctx context.Context, r slog.Record) error {
+	switch {
+	case r.Level >= slog.LevelError:
+		h.counters.errors.Add(1)
+	case r.Level >= slog.LevelWarn:
+		h.counters.warnings.Add(1)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *countingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &countingHandler{Handler: h.Handler.WithAttrs(attrs), counters: h.counters}
+}
+
+func (h *countingHandler) WithGroup(name string) slog.Handler {
+	return &countingHandler{Handler: h.Handler.WithGroup(name), counters: h.counters}
+}
+
+// Closer is returned by Init. Closing it flushes and closes the log file (if
+// any was configured); Counters tallies this run's logged warnings/errors.
+type Closer struct {
+	file     *os.File
+	Counters *LogCounters
+}
+
+// Close closes the underlying log file, if Config.File was set.
+func (c *Closer) Close() error {
+	if c.file == nil {
+		return nil
+	}
+	return c.file.Close()
+}
+
+// Init installs a process-wide slog logger per cfg and returns a Closer
+// whose Counters field tracks logged warnings/errors for the remainder of
+// the run.
+func Init(cfg *Config) (*Closer, error) {
+	var w io.Writer = os.Stderr
+	var file *os.File
+	if cfg.File != "" {
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file %s: %w", cfg.File, err)
+		}
+		file = f
+		w = io.MultiWriter(os.Stderr, f)
+	}
+
+	opts := &slog.HandlerOptions{Level: cfg.Verbosity.slogLevel()}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "json") {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+
+	counters := &LogCounters{}
+	slog.SetDefault(slog.New(&countingHandler{Handler: handler, counters: counters}))
+
+	return &Closer{file: file, Counters: counters}, nil
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestCountingHandlerTalliesWarningsAndErrors(t *testing.T) {
+	counters := &LogCounters{}
+	base := slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := slog.New(&countingHandler{Handler: base, counters: counters})
+
+	logger.Info("info message")
+	logger.Warn("warn message")
+	logger.Warn("another warn")
+	logger.Error("error message")
+
+	if got := counters.Warnings(); got != 2 {
+		t.Errorf("Warnings() = %d, want 2", got)
+	}
+	if got := counters.Errors(); got != 1 {
+		t.Errorf("Errors() = %d, want 1", got)
+	}
+}
+
+func TestParseVerbosity(t *testing.T) {
+	cases := map[string]VerbosityLevel{
+		"verbose": Verbose,
+		"Info":    Info,
+		"WARN":    Warning,
+		"warning": Warning,
+		"error":   Error,
+		"off":     Off,
+	}
+	for input, want := range cases {
+		got, err := ParseVerbosity(input)
+		if err != nil {
+			t.Errorf("ParseVerbosity(%q) returned error: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseVerbosity(%q) = %v, want %v", input, got, want)
+		}
+	}
+
+	if _, err := ParseVerbosity("bogus"); err == nil {
+		t.Error("expected an error for an unknown verbosity")
+	}
+}
@@ -0,0 +1,42 @@
+package model
+
+// FileDiff is the per-file result of comparing a file's line coverage
+// between a base and a head SummaryResult.
+type FileDiff struct {
+	Path string
+
+	// Added and Removed mark a file that only exists on one side of the
+	// diff; NewlyCovered/NewlyUncovered are empty in that case since there
+	// is nothing on the other side to compare against.
+	Added   bool
+	Removed bool
+
+	// NewlyCovered and NewlyUncovered list, in ascending order, the line
+	// numbers that flipped coverage state between base and head.
+	NewlyCovered   []int
+	NewlyUncovered []int
+}
+
+// ClassDiff groups the FileDiffs belonging to one class between base and
+// head.
+type ClassDiff struct {
+	Name  string
+	Files []*FileDiff
+}
+
+// AssemblyDiff groups the ClassDiffs belonging to one assembly between base
+// and head.
+type AssemblyDiff struct {
+	Name    string
+	Classes []*ClassDiff
+}
+
+// CoverageDiff is the full result of comparing two SummaryResult snapshots,
+// produced by analyzer.DiffSummaries.
+//
+// This is a line-coverage diff only: model.Line has no branch-coverage
+// concept to compare, so there is no branch delta here. Extending Line with
+// branch data is a prerequisite for a branch-level CoverageDiff.
+type CoverageDiff struct {
+	Assemblies []*AssemblyDiff
+}
@@ -0,0 +1,38 @@
+// Package model holds the in-memory coverage domain types that every parser
+// produces and every report builder consumes.
+package model
+
+// Line represents the coverage state of a single source line.
+type Line struct {
+	Number int
+	Hits   int
+}
+
+// CodeFile is a single source file and the coverage recorded for it.
+type CodeFile struct {
+	Path  string
+	Lines []Line
+}
+
+// Class groups the files that make up a single class or package.
+type Class struct {
+	Name  string
+	Files []*CodeFile
+}
+
+// Assembly groups the classes that make up a single assembly or module.
+type Assembly struct {
+	Name    string
+	Classes []*Class
+}
+
+// SummaryResult is the fully merged coverage data for a run, produced by
+// analyzer.MergeParserResults and consumed by every report builder.
+type SummaryResult struct {
+	Assemblies []*Assembly
+
+	// UnresolvedFiles holds zero-coverage stubs for source files that a
+	// parser referenced but that could not be found on disk, recorded here
+	// only when -ignoreerrors demotes that condition to a warning.
+	UnresolvedFiles []*CodeFile
+}
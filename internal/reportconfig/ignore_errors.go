@@ -0,0 +1,93 @@
+package reportconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorID identifies a well-known error condition that -ignoreerrors can
+// demote to a warning.
+type ErrorID string
+
+const (
+	// ErrUnresolvedSourceFile fires when a report references a source file
+	// that cannot be found on disk.
+	ErrUnresolvedSourceFile ErrorID = "unresolved-source-file"
+	// ErrParserParseError fires when a parser fails outright on a report file.
+	ErrParserParseError ErrorID = "parser-parse-error"
+	// ErrNoValidReportFiles fires when none of the resolved report files
+	// could be parsed successfully. -report patterns that resolve to no file
+	// at all still fail the run before parsing starts.
+	ErrNoValidReportFiles ErrorID = "no-valid-report-files"
+)
+
+// ValidErrorIDs returns every ErrorID understood by -ignoreerrors, in the
+// order they should be listed in help text and error messages.
+func ValidErrorIDs() []ErrorID {
+	return []ErrorID{
+		ErrUnresolvedSourceFile,
+		ErrParserParseError,
+		ErrNoValidReportFiles,
+	}
+}
+
+// ParseErrorIDs parses the comma-separated value of -ignoreerrors. An empty
+// string is valid and yields no IDs. An unrecognized ID is a flag-parse-time
+// error naming every valid ID, so the user doesn't have to consult the docs.
+func ParseErrorIDs(csv string) ([]ErrorID, error) {
+	csv = strings.TrimSpace(csv)
+	if csv == "" {
+		return nil, nil
+	}
+
+	valid := make(map[ErrorID]struct{}, len(ValidErrorIDs()))
+	for _, id := range ValidErrorIDs() {
+		valid[id] = struct{}{}
+	}
+
+	var ids []ErrorID
+	for _, part := range strings.Split(csv, ",") {
+		id := ErrorID(strings.TrimSpace(part))
+		if id == "" {
+			continue
+		}
+		if _, ok := valid[id]; !ok {
+			return nil, fmt.Errorf("unknown -ignoreerrors id %q, valid ids are: %s", id, joinErrorIDs())
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func joinErrorIDs() string {
+	ids := ValidErrorIDs()
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = string(id)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// WithIgnoredErrors records the set of error IDs that should be treated as
+// warnings instead of fatal errors for the remainder of the run.
+func WithIgnoredErrors(ids []ErrorID) Option {
+	return func(rc *ReportConfiguration) error {
+		if len(ids) == 0 {
+			return nil
+		}
+		rc.ignoredErrors = make(map[ErrorID]struct{}, len(ids))
+		for _, id := range ids {
+			rc.ignoredErrors[id] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// IsErrorIgnored reports whether id was listed in -ignoreerrors.
+func (rc *ReportConfiguration) IsErrorIgnored(id ErrorID) bool {
+	if rc == nil || rc.ignoredErrors == nil {
+		return false
+	}
+	_, ok := rc.ignoredErrors[id]
+	return ok
+}
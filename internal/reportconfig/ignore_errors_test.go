@@ -0,0 +1,51 @@
+package reportconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseErrorIDsEmpty(t *testing.T) {
+	ids, err := ParseErrorIDs("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids != nil {
+		t.Errorf("ids = %v, want nil", ids)
+	}
+}
+
+func TestParseErrorIDsValid(t *testing.T) {
+	ids, err := ParseErrorIDs(" unresolved-source-file , parser-parse-error ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []ErrorID{ErrUnresolvedSourceFile, ErrParserParseError}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+}
+
+func TestParseErrorIDsUnknown(t *testing.T) {
+	_, err := ParseErrorIDs("not-a-real-id")
+	if err == nil {
+		t.Fatal("expected an error for an unknown id")
+	}
+}
+
+func TestIsErrorIgnored(t *testing.T) {
+	rc := &ReportConfiguration{}
+	if rc.IsErrorIgnored(ErrParserParseError) {
+		t.Error("expected no ids to be ignored by default")
+	}
+
+	if err := WithIgnoredErrors([]ErrorID{ErrParserParseError})(rc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rc.IsErrorIgnored(ErrParserParseError) {
+		t.Error("expected ErrParserParseError to be ignored")
+	}
+	if rc.IsErrorIgnored(ErrUnresolvedSourceFile) {
+		t.Error("expected ErrUnresolvedSourceFile to remain non-ignored")
+	}
+}
@@ -0,0 +1,137 @@
+// Package reportconfig holds the fully resolved configuration for a single
+// report-generation run, built up from CLI flags via the functional-options
+// pattern so new settings can be added without breaking existing callers.
+package reportconfig
+
+import (
+	"log/slog"
+
+	"github.com/IgorBayerl/AdlerCov/internal/language"
+	"github.com/IgorBayerl/AdlerCov/internal/logging"
+)
+
+// ReportConfiguration is the immutable result of parsing and validating CLI
+// flags. It is constructed once via NewReportConfiguration and read by every
+// downstream stage (parsing, merging, report building).
+type ReportConfiguration struct {
+	reportFiles     []string
+	targetDirectory string
+
+	logger    *slog.Logger
+	verbosity logging.VerbosityLevel
+
+	invalidPatterns []string
+	title           string
+	tag             string
+
+	sourceDirectories []string
+	reportTypes       []string
+
+	assemblyFilters   []string
+	classFilters      []string
+	fileFilters       []string
+	rhAssemblyFilters []string
+	rhClassFilters    []string
+
+	langFactory *language.ProcessorFactory
+
+	ignoredErrors map[ErrorID]struct{}
+}
+
+// Option configures a ReportConfiguration during construction. Options are
+// applied in order and may also be re-applied later (e.g. when a parser
+// discovers source directories embedded in a report file).
+type Option func(*ReportConfiguration) error
+
+// NewReportConfiguration builds a ReportConfiguration from the resolved
+// report files, the output directory, and any number of Options.
+func NewReportConfiguration(reportFiles []string, targetDirectory string, opts ...Option) (*ReportConfiguration, error) {
+	rc := &ReportConfiguration{
+		reportFiles:     reportFiles,
+		targetDirectory: targetDirectory,
+	}
+	for _, opt := range opts {
+		if err := opt(rc); err != nil {
+			return nil, err
+		}
+	}
+	return rc, nil
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.logger = logger
+		return nil
+	}
+}
+
+func WithVerbosity(v logging.VerbosityLevel) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.verbosity = v
+		return nil
+	}
+}
+
+func WithInvalidPatterns(patterns []string) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.invalidPatterns = patterns
+		return nil
+	}
+}
+
+func WithTitle(title string) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.title = title
+		return nil
+	}
+}
+
+func WithTag(tag string) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.tag = tag
+		return nil
+	}
+}
+
+func WithSourceDirectories(dirs []string) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.sourceDirectories = dirs
+		return nil
+	}
+}
+
+func WithReportTypes(types []string) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.reportTypes = types
+		return nil
+	}
+}
+
+func WithFilters(assembly, class, file, rhAssembly, rhClass []string) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.assemblyFilters = assembly
+		rc.classFilters = class
+		rc.fileFilters = file
+		rc.rhAssemblyFilters = rhAssembly
+		rc.rhClassFilters = rhClass
+		return nil
+	}
+}
+
+func WithLanguageProcessorFactory(f *language.ProcessorFactory) Option {
+	return func(rc *ReportConfiguration) error {
+		rc.langFactory = f
+		return nil
+	}
+}
+
+func (rc *ReportConfiguration) ReportFiles() []string       { return rc.reportFiles }
+func (rc *ReportConfiguration) TargetDirectory() string     { return rc.targetDirectory }
+func (rc *ReportConfiguration) SourceDirectories() []string { return rc.sourceDirectories }
+func (rc *ReportConfiguration) ReportTypes() []string       { return rc.reportTypes }
+func (rc *ReportConfiguration) Title() string               { return rc.title }
+func (rc *ReportConfiguration) Tag() string                 { return rc.tag }
+func (rc *ReportConfiguration) Logger() *slog.Logger        { return rc.logger }
+func (rc *ReportConfiguration) LanguageProcessorFactory() *language.ProcessorFactory {
+	return rc.langFactory
+}
@@ -1,6 +1,41 @@
 package reporter
 
-import "github.com/IgorBayerl/AdlerCov/internal/model"
+import (
+	"log/slog"
+
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+	"github.com/IgorBayerl/AdlerCov/internal/reportconfig"
+	"github.com/IgorBayerl/AdlerCov/internal/settings"
+)
+
+// IBuilderContext bundles the per-run state a ReportBuilder needs beyond the
+// coverage data itself: the resolved ReportConfiguration, the tool-identity
+// Settings, and the logger. Passing this one value instead of three lets
+// NewBuilderContext grow without changing every builder constructor's
+// signature.
+type IBuilderContext interface {
+	ReportConfiguration() *reportconfig.ReportConfiguration
+	Settings() *settings.Settings
+	Logger() *slog.Logger
+}
+
+type builderContext struct {
+	reportConfig *reportconfig.ReportConfiguration
+	settings     *settings.Settings
+	logger       *slog.Logger
+}
+
+// NewBuilderContext builds the IBuilderContext shared by every report
+// builder constructed for a single run.
+func NewBuilderContext(reportConfig *reportconfig.ReportConfiguration, s *settings.Settings, logger *slog.Logger) IBuilderContext {
+	return &builderContext{reportConfig: reportConfig, settings: s, logger: logger}
+}
+
+func (b *builderContext) ReportConfiguration() *reportconfig.ReportConfiguration {
+	return b.reportConfig
+}
+func (b *builderContext) Settings() *settings.Settings { return b.settings }
+func (b *builderContext) Logger() *slog.Logger         { return b.logger }
 
 // ReportBuilder interface defines methods that all report generators must implement
 type ReportBuilder interface {
@@ -10,3 +45,14 @@ type ReportBuilder interface {
 	// CreateReport generates the report from the coverage data
 	CreateReport(report *model.SummaryResult) error
 }
+
+// DiffReportBuilder is the diff-report analogue of ReportBuilder: it renders
+// the delta between two coverage snapshots produced by
+// analyzer.DiffSummaries instead of a single merged SummaryResult.
+type DiffReportBuilder interface {
+	// ReportType returns the type of report this builder generates
+	ReportType() string
+
+	// CreateReport generates the report from the coverage diff
+	CreateReport(diff *model.CoverageDiff) error
+}
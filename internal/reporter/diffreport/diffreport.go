@@ -0,0 +1,169 @@
+// Package diffreport renders a model.CoverageDiff -- the output of
+// analyzer.DiffSummaries -- as a single coverage-diff report bundle: a
+// machine-readable JSON file, a human-readable text summary, and an HTML
+// view that colors added/removed coverage inline on the actual source text,
+// reusing htmlreport.RenderSourceLines for the per-line rendering.
+package diffreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter/htmlreport"
+)
+
+// Builder implements reporter.DiffReportBuilder. Unlike the report builders
+// that each emit one format (TextSummary, Html, Lcov), a single CreateReport
+// call writes all three artifacts -- diff.json, diff-summary.txt and diff.html --
+// since a diff is always meant to be consumed in whichever of those forms
+// the caller (CI, a human, GitHub) needs.
+type Builder struct {
+	targetDirectory string
+	logger          *slog.Logger
+}
+
+// NewDiffReportBuilder creates a Builder that writes its report files into
+// targetDirectory.
+func NewDiffReportBuilder(targetDirectory string, logger *slog.Logger) *Builder {
+	return &Builder{targetDirectory: targetDirectory, logger: logger}
+}
+
+// ReportType returns the type of report this builder generates.
+func (b *Builder) ReportType() string { return "Diff" }
+
+// CreateReport writes diff.json, diff-summary.txt and diff.html for diff
+// into the builder's target directory.
+func (b *Builder) CreateReport(diff *model.CoverageDiff) error {
+	if err := os.MkdirAll(b.targetDirectory, 0o755); err != nil {
+		return fmt.Errorf("failed to create diff report directory: %w", err)
+	}
+
+	if err := b.writeJSON(diff); err != nil {
+		return err
+	}
+	if err := b.writeTextSummary(diff); err != nil {
+		return err
+	}
+	if err := b.writeHTML(diff); err != nil {
+		return err
+	}
+
+	b.logf("Diff report written", "directory", b.targetDirectory)
+	return nil
+}
+
+func (b *Builder) writeJSON(diff *model.CoverageDiff) error {
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal coverage diff: %w", err)
+	}
+	path := filepath.Join(b.targetDirectory, "diff.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (b *Builder) writeTextSummary(diff *model.CoverageDiff) error {
+	var sb strings.Builder
+	sb.WriteString("Coverage Diff Summary\n")
+	sb.WriteString("=====================\n\n")
+
+	totalCovered, totalUncovered, totalAdded, totalRemoved := 0, 0, 0, 0
+	for _, assembly := range diff.Assemblies {
+		fmt.Fprintf(&sb, "Assembly: %s\n", assembly.Name)
+		for _, class := range assembly.Classes {
+			for _, file := range class.Files {
+				switch {
+				case file.Added:
+					totalAdded++
+					fmt.Fprintf(&sb, "  + %s (new file)\n", file.Path)
+				case file.Removed:
+					totalRemoved++
+					fmt.Fprintf(&sb, "  - %s (removed file)\n", file.Path)
+				case len(file.NewlyCovered) > 0 || len(file.NewlyUncovered) > 0:
+					totalCovered += len(file.NewlyCovered)
+					totalUncovered += len(file.NewlyUncovered)
+					fmt.Fprintf(&sb, "  ~ %s (+%d covered, -%d covered)\n", file.Path, len(file.NewlyCovered), len(file.NewlyUncovered))
+				}
+			}
+		}
+	}
+
+	fmt.Fprintf(&sb, "\nTotals: %d lines newly covered, %d lines newly uncovered, %d files added, %d files removed\n",
+		totalCovered, totalUncovered, totalAdded, totalRemoved)
+
+	path := filepath.Join(b.targetDirectory, "diff-summary.txt")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (b *Builder) writeHTML(diff *model.CoverageDiff) error {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Coverage Diff</title>\n")
+	sb.WriteString(htmlreport.SourceStyleBlock())
+	sb.WriteString("<style>\n.file-added,.file-removed{font-weight:bold;}\n.file-added{color:#2e7d32;}\n.file-removed{color:#c62828;}\n</style>\n")
+	sb.WriteString("</head><body>\n<h1>Coverage Diff</h1>\n")
+
+	for _, assembly := range diff.Assemblies {
+		fmt.Fprintf(&sb, "<h2>%s</h2>\n", html.EscapeString(assembly.Name))
+		for _, class := range assembly.Classes {
+			fmt.Fprintf(&sb, "<h3>%s</h3>\n", html.EscapeString(class.Name))
+			for _, file := range class.Files {
+				sb.WriteString(renderFileDiff(file))
+			}
+		}
+	}
+
+	sb.WriteString("</body></html>\n")
+
+	path := filepath.Join(b.targetDirectory, "diff.html")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// renderFileDiff renders one file's section of diff.html: for an
+// added/removed file, just a heading; for a changed file, the same
+// colored-source-line view htmlreport produces, with NewlyCovered/
+// NewlyUncovered lines tagged "added"/"removed" instead of htmlreport's own
+// "covered"/"uncovered" (SourceStyleBlock colors both pairs the same way).
+func renderFileDiff(file *model.FileDiff) string {
+	escaped := html.EscapeString(file.Path)
+	switch {
+	case file.Added:
+		return fmt.Sprintf("<h4 class=\"file-added\">+ %s (new file)</h4>\n", escaped)
+	case file.Removed:
+		return fmt.Sprintf("<h4 class=\"file-removed\">- %s (removed file)</h4>\n", escaped)
+	}
+
+	lineClasses := make(map[int]string, len(file.NewlyCovered)+len(file.NewlyUncovered))
+	for _, n := range file.NewlyCovered {
+		lineClasses[n] = "added"
+	}
+	for _, n := range file.NewlyUncovered {
+		lineClasses[n] = "removed"
+	}
+
+	source, err := htmlreport.RenderSourceLines(file.Path, lineClasses)
+	if err != nil {
+		return fmt.Sprintf("<h4>%s</h4>\n<p>Source unavailable: %s</p>\n", escaped, html.EscapeString(err.Error()))
+	}
+	return fmt.Sprintf("<h4>%s</h4>\n%s", escaped, source)
+}
+
+func (b *Builder) logf(msg string, args ...any) {
+	if b.logger == nil {
+		return
+	}
+	b.logger.Info(msg, args...)
+}
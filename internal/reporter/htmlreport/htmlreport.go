@@ -0,0 +1,213 @@
+// Package htmlreport renders coverage as a static HTML site: index.html
+// lists every assembly/class/file with its line coverage, and each source
+// file gets its own page with every line colored by coverage state.
+package htmlreport
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter"
+)
+
+const (
+	coveredClass   = "covered"
+	uncoveredClass = "uncovered"
+)
+
+// Option configures optional Builder behavior, e.g. WithLiveReload.
+type Option func(*Builder)
+
+// Builder implements reporter.ReportBuilder, emitting a static HTML
+// coverage site rooted at targetDirectory.
+type Builder struct {
+	targetDirectory    string
+	reportCtx          reporter.IBuilderContext
+	liveReloadEndpoint string
+}
+
+// NewHtmlReportBuilder creates a Builder that writes its HTML site into
+// targetDirectory.
+func NewHtmlReportBuilder(targetDirectory string, reportCtx reporter.IBuilderContext, opts ...Option) *Builder {
+	b := &Builder{targetDirectory: targetDirectory, reportCtx: reportCtx}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithLiveReload injects a script into every generated page that opens a
+// Server-Sent-Events connection to endpoint: a "reload" event refreshes the
+// page, an "error" event shows its message as an in-page overlay. Used by
+// the serve subcommand so open browser tabs pick up rebuilds automatically.
+func WithLiveReload(endpoint string) Option {
+	return func(b *Builder) {
+		b.liveReloadEndpoint = endpoint
+	}
+}
+
+// ReportType returns the type of report this builder generates.
+func (b *Builder) ReportType() string { return "Html" }
+
+// CreateReport writes index.html and one page per source file into the
+// builder's target directory.
+func (b *Builder) CreateReport(summary *model.SummaryResult) error {
+	if err := os.MkdirAll(b.targetDirectory, 0o755); err != nil {
+		return fmt.Errorf("failed to create HTML report directory: %w", err)
+	}
+
+	var index strings.Builder
+	index.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Coverage Report</title>\n")
+	index.WriteString(SourceStyleBlock())
+	b.writeLiveReloadScript(&index)
+	index.WriteString("</head><body>\n<h1>Coverage Report</h1>\n")
+
+	for _, assembly := range summary.Assemblies {
+		fmt.Fprintf(&index, "<h2>%s</h2>\n", html.EscapeString(assembly.Name))
+		for _, class := range assembly.Classes {
+			fmt.Fprintf(&index, "<h3>%s</h3>\n<ul>\n", html.EscapeString(class.Name))
+			for _, file := range class.Files {
+				covered, total := lineTotals(file)
+				pageName := pageFileName(file.Path)
+				fmt.Fprintf(&index, "<li><a href=\"%s\">%s</a> (%d/%d lines covered)</li>\n",
+					html.EscapeString(pageName), html.EscapeString(file.Path), covered, total)
+
+				if err := b.writeFilePage(pageName, file); err != nil {
+					return err
+				}
+			}
+			index.WriteString("</ul>\n")
+		}
+	}
+	index.WriteString("</body></html>\n")
+
+	path := filepath.Join(b.targetDirectory, "index.html")
+	if err := os.WriteFile(path, []byte(index.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (b *Builder) writeFilePage(pageName string, file *model.CodeFile) error {
+	lineClasses := make(map[int]string, len(file.Lines))
+	for _, line := range file.Lines {
+		if line.Hits > 0 {
+			lineClasses[line.Number] = coveredClass
+		} else {
+			lineClasses[line.Number] = uncoveredClass
+		}
+	}
+
+	source, err := RenderSourceLines(file.Path, lineClasses)
+	if err != nil {
+		source = fmt.Sprintf("<p>Source unavailable: %s</p>\n", html.EscapeString(err.Error()))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>")
+	sb.WriteString(html.EscapeString(file.Path))
+	sb.WriteString("</title>\n")
+	sb.WriteString(SourceStyleBlock())
+	b.writeLiveReloadScript(&sb)
+	sb.WriteString("</head><body>\n")
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(file.Path))
+	sb.WriteString(source)
+	sb.WriteString("</body></html>\n")
+
+	path := filepath.Join(b.targetDirectory, pageName)
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeLiveReloadScript appends the live-reload script block to sb; a no-op
+// unless WithLiveReload was configured.
+func (b *Builder) writeLiveReloadScript(sb *strings.Builder) {
+	if b.liveReloadEndpoint == "" {
+		return
+	}
+	fmt.Fprintf(sb, liveReloadScriptTemplate, b.liveReloadEndpoint)
+}
+
+const liveReloadScriptTemplate = `<style>
+#adlercov-error-overlay{display:none;position:fixed;inset:0;background:rgba(20,0,0,.92);color:#fff;
+font-family:monospace;white-space:pre-wrap;padding:2rem;z-index:9999;overflow:auto;}
+</style>
+<div id="adlercov-error-overlay"></div>
+<script>
+(function(){
+  var overlay = document.getElementById("adlercov-error-overlay");
+  var es = new EventSource(%q);
+  es.addEventListener("reload", function(){ location.reload(); });
+  es.addEventListener("error", function(e){
+    if (!e.data) { return; }
+    overlay.textContent = e.data;
+    overlay.style.display = "block";
+  });
+})();
+</script>
+`
+
+// RenderSourceLines reads the source file at path and renders it as an HTML
+// table, one row per line, tagging row i+1 with lineClasses[i+1] (e.g.
+// "covered"/"uncovered") when present. Exported so other report builders
+// that need the same colored-source-line view -- diffreport's inline
+// added/removed coverage -- can reuse this rendering instead of duplicating
+// it with their own line-class vocabulary (e.g. "added"/"removed").
+func RenderSourceLines(path string, lineClasses map[int]string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source file %s: %w", path, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var sb strings.Builder
+	sb.WriteString("<table class=\"source\">\n")
+	for i, text := range lines {
+		num := i + 1
+		rowClass := ""
+		if class, ok := lineClasses[num]; ok {
+			rowClass = fmt.Sprintf(" class=%q", class)
+		}
+		fmt.Fprintf(&sb, "<tr%s><td class=\"lineno\">%d</td><td class=\"linetext\">%s</td></tr>\n",
+			rowClass, num, html.EscapeString(text))
+	}
+	sb.WriteString("</table>\n")
+	return sb.String(), nil
+}
+
+// SourceStyleBlock is the CSS shared by every page rendering
+// RenderSourceLines output, exported so callers embedding that HTML into
+// their own page (e.g. diffreport) can reuse the same look.
+func SourceStyleBlock() string {
+	return `<style>
+table.source{border-collapse:collapse;font-family:monospace;font-size:13px;}
+table.source td.lineno{color:#888;text-align:right;padding-right:8px;user-select:none;}
+table.source td.linetext{white-space:pre;}
+table.source tr.covered{background-color:#d4f8d4;}
+table.source tr.uncovered{background-color:#f8d4d4;}
+table.source tr.added{background-color:#d4f8d4;}
+table.source tr.removed{background-color:#f8d4d4;}
+</style>
+`
+}
+
+func pageFileName(path string) string {
+	sanitized := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(path)
+	return sanitized + ".html"
+}
+
+func lineTotals(file *model.CodeFile) (covered, total int) {
+	for _, line := range file.Lines {
+		total++
+		if line.Hits > 0 {
+			covered++
+		}
+	}
+	return covered, total
+}
@@ -0,0 +1,92 @@
+// Package metricsreport renders coverage as an OpenMetrics/Prometheus text
+// exposition file, so coverage can be scraped and tracked over time
+// alongside a project's other operational metrics.
+package metricsreport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+)
+
+// Builder implements reporter.ReportBuilder, emitting coverage.prom.
+type Builder struct {
+	targetDirectory string
+}
+
+// NewMetricsReportBuilder creates a Builder that writes coverage.prom into
+// targetDirectory.
+func NewMetricsReportBuilder(targetDirectory string) *Builder {
+	return &Builder{targetDirectory: targetDirectory}
+}
+
+// ReportType returns the type of report this builder generates.
+func (b *Builder) ReportType() string { return "OpenMetrics" }
+
+// CreateReport writes coverage.prom: a coverage_line_ratio and
+// coverage_lines_total gauge per assembly/class, plus a top-level
+// coverage_generated_timestamp_seconds gauge.
+//
+// There is no coverage_branch_ratio metric: model.Line carries no branch
+// data, only hit counts for whole lines, so this report is line coverage
+// only. Add branch tracking to the model before promising a branch metric
+// here.
+func (b *Builder) CreateReport(summary *model.SummaryResult) error {
+	if err := os.MkdirAll(b.targetDirectory, 0o755); err != nil {
+		return fmt.Errorf("failed to create OpenMetrics report directory: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# HELP coverage_line_ratio Ratio of covered lines to total lines.\n")
+	sb.WriteString("# TYPE coverage_line_ratio gauge\n")
+	for _, assembly := range summary.Assemblies {
+		for _, class := range assembly.Classes {
+			covered, total := lineTotals(class)
+			ratio := 0.0
+			if total > 0 {
+				ratio = float64(covered) / float64(total)
+			}
+			fmt.Fprintf(&sb, "coverage_line_ratio{assembly=%q,class=%q} %s\n", assembly.Name, class.Name, formatFloat(ratio))
+		}
+	}
+
+	sb.WriteString("# HELP coverage_lines_total Total number of coverable lines.\n")
+	sb.WriteString("# TYPE coverage_lines_total gauge\n")
+	for _, assembly := range summary.Assemblies {
+		for _, class := range assembly.Classes {
+			_, total := lineTotals(class)
+			fmt.Fprintf(&sb, "coverage_lines_total{assembly=%q,class=%q} %d\n", assembly.Name, class.Name, total)
+		}
+	}
+
+	sb.WriteString("# HELP coverage_generated_timestamp_seconds Unix time the report was generated.\n")
+	sb.WriteString("# TYPE coverage_generated_timestamp_seconds gauge\n")
+	fmt.Fprintf(&sb, "coverage_generated_timestamp_seconds %d\n", time.Now().Unix())
+	sb.WriteString("# EOF\n")
+
+	path := filepath.Join(b.targetDirectory, "coverage.prom")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func lineTotals(class *model.Class) (covered, total int) {
+	for _, file := range class.Files {
+		for _, line := range file.Lines {
+			total++
+			if line.Hits > 0 {
+				covered++
+			}
+		}
+	}
+	return covered, total
+}
+
+func formatFloat(f float64) string {
+	return fmt.Sprintf("%.4f", f)
+}
@@ -0,0 +1,194 @@
+// Package sarifreport renders coverage as a SARIF 2.1.0 log: every
+// uncovered line becomes a "note"-level result with ruleId
+// "coverage/uncovered-line", which lets GitHub Code Scanning surface
+// uncovered lines inline on a pull request diff.
+package sarifreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IgorBayerl/AdlerCov/internal/model"
+	"github.com/IgorBayerl/AdlerCov/internal/reporter"
+)
+
+const (
+	sarifSchema  = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	ruleID         = "coverage/uncovered-line"
+	uncoveredLevel = "note"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool      sarifTool       `json:"tool"`
+	Artifacts []sarifArtifact `json:"artifacts"`
+	Results   []sarifResult   `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string          `json:"id"`
+	Name                 string          `json:"name"`
+	ShortDescription     sarifText       `json:"shortDescription"`
+	DefaultConfiguration sarifRuleConfig `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifArtifact struct {
+	Location sarifArtifactLocation `json:"location"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifResult struct {
+	RuleID    string                `json:"ruleId"`
+	Level     string                `json:"level"`
+	Message   sarifText             `json:"message"`
+	Locations []sarifResultLocation `json:"locations"`
+}
+
+type sarifResultLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// Builder implements reporter.ReportBuilder, emitting coverage.sarif.
+type Builder struct {
+	targetDirectory string
+	reportCtx       reporter.IBuilderContext
+}
+
+// NewSarifReportBuilder creates a Builder that writes coverage.sarif into
+// targetDirectory.
+func NewSarifReportBuilder(targetDirectory string, reportCtx reporter.IBuilderContext) *Builder {
+	return &Builder{targetDirectory: targetDirectory, reportCtx: reportCtx}
+}
+
+// ReportType returns the type of report this builder generates.
+func (b *Builder) ReportType() string { return "Sarif" }
+
+// CreateReport writes coverage.sarif, expressing every uncovered line in
+// summary as a SARIF result.
+func (b *Builder) CreateReport(summary *model.SummaryResult) error {
+	if err := os.MkdirAll(b.targetDirectory, 0o755); err != nil {
+		return fmt.Errorf("failed to create SARIF report directory: %w", err)
+	}
+
+	toolSettings := b.reportCtx.Settings()
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           toolSettings.ToolName,
+				Version:        toolSettings.ToolVersion,
+				InformationURI: toolSettings.InfoURI,
+				Rules: []sarifRule{{
+					ID:                   ruleID,
+					Name:                 "UncoveredLine",
+					ShortDescription:     sarifText{Text: "Line is not covered by any test"},
+					DefaultConfiguration: sarifRuleConfig{Level: uncoveredLevel},
+				}},
+			},
+		},
+	}
+
+	seenArtifacts := make(map[string]struct{})
+	for _, assembly := range summary.Assemblies {
+		for _, class := range assembly.Classes {
+			for _, file := range class.Files {
+				uri := toArtifactURI(file.Path, b.reportCtx.ReportConfiguration().SourceDirectories())
+				if _, ok := seenArtifacts[uri]; !ok {
+					run.Artifacts = append(run.Artifacts, sarifArtifact{Location: sarifArtifactLocation{URI: uri}})
+					seenArtifacts[uri] = struct{}{}
+				}
+
+				for _, line := range file.Lines {
+					if line.Hits > 0 {
+						continue
+					}
+					run.Results = append(run.Results, sarifResult{
+						RuleID: ruleID,
+						Level:  uncoveredLevel,
+						Message: sarifText{
+							Text: fmt.Sprintf("Line %d in %s is not covered by any test", line.Number, file.Path),
+						},
+						Locations: []sarifResultLocation{{
+							PhysicalLocation: sarifPhysicalLocation{
+								ArtifactLocation: sarifArtifactLocation{URI: uri},
+								Region:           sarifRegion{StartLine: line.Number},
+							},
+						}},
+					})
+				}
+			}
+		}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal SARIF log: %w", err)
+	}
+
+	path := filepath.Join(b.targetDirectory, "coverage.sarif")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// toArtifactURI normalizes path into the forward-slash relative URI SARIF
+// expects for artifactLocation.uri. GitHub Code Scanning resolves that URI
+// against the checked-out repository root, so an absolute filesystem path
+// won't match; path is made relative to whichever sourceDir contains it
+// before slash-normalizing. If path isn't under any sourceDir, or isn't
+// absolute, it falls back to a plain slash-normalized path.
+func toArtifactURI(path string, sourceDirs []string) string {
+	for _, dir := range sourceDirs {
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(path)
+}
@@ -0,0 +1,29 @@
+// Package settings holds process-wide tool-identity values -- this build of
+// AdlerCov's own name, version and homepage -- used by report builders that
+// self-describe (e.g. SARIF's driver block). This is distinct from
+// reportconfig.ReportConfiguration, which holds per-run user input like
+// -tag and -sourcedirs.
+package settings
+
+// Version is the AdlerCov build version. It is "dev" unless overridden at
+// build time, e.g.:
+//
+//	go build -ldflags "-X github.com/IgorBayerl/AdlerCov/internal/settings.Version=v1.2.3"
+var Version = "dev"
+
+// Settings holds the tool-identity values shared by every report builder in
+// a run.
+type Settings struct {
+	ToolName    string
+	ToolVersion string
+	InfoURI     string
+}
+
+// NewSettings returns the default Settings for this build of AdlerCov.
+func NewSettings() *Settings {
+	return &Settings{
+		ToolName:    "AdlerCov",
+		ToolVersion: Version,
+		InfoURI:     "https://github.com/IgorBayerl/AdlerCov",
+	}
+}